@@ -2,7 +2,9 @@ package types
 
 import (
 	"errors"
+	"iter"
 	"math/big"
+	"sort"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -37,6 +39,25 @@ type Game interface {
 	// AncestorWithTraceIndex finds the ancestor of claim with trace index idx if present.
 	// Returns the claim and true if the ancestor is found, or Claim{}, false if not.
 	AncestorWithTraceIndex(claim Claim, idx *big.Int) (Claim, bool)
+
+	// Children returns the direct children of claim, sorted left-to-right by
+	// position (ties broken by submission order).
+	Children(claim Claim) []Claim
+
+	// Descendants iterates all descendants of claim in breadth-first order.
+	// Stops early if the consumer returns false from the yield function.
+	Descendants(claim Claim) iter.Seq[Claim]
+
+	// Subtree returns a Game view rooted at claim: Claims only returns claim
+	// and its descendants, and GetParent(claim) itself returns
+	// ErrClaimNotFound since claim has no parent within the view.
+	Subtree(claim Claim) Game
+
+	// DescendantWithTraceIndex finds the descendant of claim with trace
+	// index idx if present, by binary-searching the trace-index range
+	// covered by each child's position rather than walking to the leaves.
+	// Returns the claim and true if found, or Claim{}, false if not.
+	DescendantWithTraceIndex(claim Claim, idx *big.Int) (Claim, bool)
 }
 
 type claimID common.Hash
@@ -55,20 +76,46 @@ type gameState struct {
 	// claims is the list of claims in the same order as the contract
 	claims   []Claim
 	claimIDs map[claimID]bool
+	// claimIndices maps a claim's ID to its index in claims, so a Claim
+	// value can be resolved back to its position in the contract-index
+	// ordered slice without a linear scan.
+	claimIndices map[claimID]int
+	// children maps a parent's contract index to the contract indices of
+	// its direct children, sorted left-to-right by position (ties, i.e.
+	// claims disagreeing at the same position, keep their relative
+	// contract-index/submission order). Built once at construction time so
+	// descendant walks don't have to rescan the whole claims slice, and kept
+	// position-sorted so DescendantWithTraceIndex can binary search it.
+	children map[int][]int
 	depth    Depth
 }
 
 // NewGameState returns a new game state.
 // The provided [Claim] is used as the root node.
 func NewGameState(claims []Claim, depth Depth) *gameState {
-	claimIDs := make(map[claimID]bool)
-	for _, claim := range claims {
-		claimIDs[computeClaimID(claim)] = true
+	claimIDs := make(map[claimID]bool, len(claims))
+	claimIndices := make(map[claimID]int, len(claims))
+	children := make(map[int][]int)
+	for i, claim := range claims {
+		id := computeClaimID(claim)
+		claimIDs[id] = true
+		claimIndices[id] = i
+		if !claim.IsRoot() {
+			children[claim.ParentContractIndex] = append(children[claim.ParentContractIndex], i)
+		}
+	}
+	for parentIdx, childIdxs := range children {
+		sort.SliceStable(childIdxs, func(a, b int) bool {
+			return claims[childIdxs[a]].Position.IndexAtDepth().Cmp(claims[childIdxs[b]].Position.IndexAtDepth()) < 0
+		})
+		children[parentIdx] = childIdxs
 	}
 	return &gameState{
-		claims:   claims,
-		claimIDs: claimIDs,
-		depth:    depth,
+		claims:       claims,
+		claimIDs:     claimIDs,
+		claimIndices: claimIndices,
+		children:     children,
+		depth:        depth,
 	}
 }
 
@@ -139,3 +186,124 @@ func (g *gameState) AncestorWithTraceIndex(claim Claim, idx *big.Int) (Claim, bo
 		claim = *next
 	}
 }
+
+// Children returns the direct children of claim, sorted left-to-right by
+// position (ties broken by submission order).
+// Returns nil if claim is not part of the game state or has no children.
+func (g *gameState) Children(claim Claim) []Claim {
+	idx, ok := g.claimIndices[computeClaimID(claim)]
+	if !ok {
+		return nil
+	}
+	childIdxs := g.children[idx]
+	if len(childIdxs) == 0 {
+		return nil
+	}
+	children := make([]Claim, len(childIdxs))
+	for i, ci := range childIdxs {
+		children[i] = g.claims[ci]
+	}
+	return children
+}
+
+// Descendants iterates all descendants of claim in breadth-first order.
+func (g *gameState) Descendants(claim Claim) iter.Seq[Claim] {
+	return func(yield func(Claim) bool) {
+		queue := g.Children(claim)
+		for len(queue) > 0 {
+			next := queue[0]
+			queue = queue[1:]
+			if !yield(next) {
+				return
+			}
+			queue = append(queue, g.Children(next)...)
+		}
+	}
+}
+
+// Subtree returns a Game view rooted at claim: Claims only returns claim and
+// its descendants, and GetParent(claim) itself returns ErrClaimNotFound
+// since claim has no parent within the view.
+func (g *gameState) Subtree(claim Claim) Game {
+	rootID := computeClaimID(claim)
+	members := map[claimID]bool{rootID: true}
+	for descendant := range g.Descendants(claim) {
+		members[computeClaimID(descendant)] = true
+	}
+	return &subtreeGame{gameState: g, rootID: rootID, members: members}
+}
+
+// DescendantWithTraceIndex finds the descendant of claim with trace index
+// idx if present. Rather than walking down to the leaves one claim at a
+// time, it binary-searches the trace-index range covered by each child's
+// position to pick the single branch that could contain idx.
+func (g *gameState) DescendantWithTraceIndex(claim Claim, idx *big.Int) (Claim, bool) {
+	current := claim
+	for {
+		if current.Position.TraceIndex(g.depth).Cmp(idx) == 0 {
+			return current, true
+		}
+		children := g.Children(current)
+		if len(children) == 0 {
+			return Claim{}, false
+		}
+		next, ok := g.childCovering(children, idx)
+		if !ok {
+			return Claim{}, false
+		}
+		current = next
+	}
+}
+
+// childCovering binary searches children, which are ordered left-to-right by
+// position, for the one whose trace-index range could contain idx.
+func (g *gameState) childCovering(children []Claim, idx *big.Int) (Claim, bool) {
+	lo, hi := 0, len(children)-1
+	for lo <= hi {
+		mid := (lo + hi) / 2
+		cmp := children[mid].Position.TraceIndex(g.depth).Cmp(idx)
+		switch {
+		case cmp == 0:
+			return children[mid], true
+		case cmp < 0:
+			lo = mid + 1
+		default:
+			hi = mid - 1
+		}
+	}
+	// No child's range boundary matched idx exactly. Multiple claims may
+	// share a position at this depth (disagreeing claimants); lo now points
+	// at the leftmost child whose range extends beyond idx, which is the
+	// branch that covers it.
+	if lo < len(children) {
+		return children[lo], true
+	}
+	return Claim{}, false
+}
+
+// subtreeGame is a Game view rooted at a single claim, returned by
+// gameState.Subtree. It embeds the parent gameState so every method not
+// overridden here (e.g. MaxDepth, AgreeWithClaimLevel, IsDuplicate) behaves
+// exactly as it would on the full game.
+type subtreeGame struct {
+	*gameState
+	rootID  claimID
+	members map[claimID]bool
+}
+
+func (s *subtreeGame) Claims() []Claim {
+	claims := make([]Claim, 0, len(s.members))
+	for _, claim := range s.gameState.claims {
+		if s.members[computeClaimID(claim)] {
+			claims = append(claims, claim)
+		}
+	}
+	return claims
+}
+
+func (s *subtreeGame) GetParent(claim Claim) (Claim, error) {
+	if computeClaimID(claim) == s.rootID {
+		return Claim{}, ErrClaimNotFound
+	}
+	return s.gameState.GetParent(claim)
+}
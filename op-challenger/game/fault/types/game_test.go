@@ -0,0 +1,130 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func claimAt(parentIdx int, depth int, indexAtDepth int64) Claim {
+	return Claim{
+		ClaimData: ClaimData{
+			Value:    common.BigToHash(big.NewInt(indexAtDepth)),
+			Position: NewPosition(depth, big.NewInt(indexAtDepth)),
+		},
+		ParentContractIndex: parentIdx,
+	}
+}
+
+// TestNewGameState_ChildrenSortedByPosition guards against the children
+// index being trusted in submission (contract-index) order: two children of
+// the same parent are added out of left-to-right order here, exactly as
+// independent challengers submitting concurrently would produce on-chain.
+func TestNewGameState_ChildrenSortedByPosition(t *testing.T) {
+	root := claimAt(0, 0, 0)
+	right := claimAt(0, 1, 3) // submitted first, but sits right of left
+	left := claimAt(0, 1, 2)  // submitted second, but sits left of right
+
+	g := NewGameState([]Claim{root, right, left}, 4)
+
+	children := g.Children(root)
+	require.Len(t, children, 2)
+	require.True(t, children[0].Position.IndexAtDepth().Cmp(children[1].Position.IndexAtDepth()) < 0,
+		"Children must be sorted left-to-right by position, not by submission order")
+}
+
+// TestGameState_DescendantWithTraceIndex_OutOfOrderSubmission reproduces the
+// binary search bug directly: childCovering must still find the correct
+// descendant when siblings were submitted out of left-right order.
+func TestGameState_DescendantWithTraceIndex_OutOfOrderSubmission(t *testing.T) {
+	root := claimAt(0, 0, 0)
+	// four grandchildren at depth 2, submitted in a shuffled order.
+	c3 := claimAt(0, 1, 1)
+	leaves := []Claim{
+		claimAt(1, 2, 3),
+		claimAt(1, 2, 1),
+		claimAt(1, 2, 0),
+		claimAt(1, 2, 2),
+	}
+
+	claims := append([]Claim{root, c3}, leaves...)
+	g := NewGameState(claims, 2)
+
+	found, ok := g.DescendantWithTraceIndex(root, big.NewInt(2))
+	require.True(t, ok)
+	require.Equal(t, int64(2), found.Position.IndexAtDepth().Int64())
+}
+
+// twoLevelTree builds root -> {a, b} -> {a1, a2 under a; b1 under b}, with
+// claims in contract-submission order [root, a, b, a1, a2, b1].
+func twoLevelTree() (g *gameState, root, a, b, a1, a2, b1 Claim) {
+	root = claimAt(0, 0, 0)
+	a = claimAt(0, 1, 0)
+	b = claimAt(0, 1, 1)
+	a1 = claimAt(1, 2, 0)
+	a2 = claimAt(1, 2, 1)
+	b1 = claimAt(2, 2, 2)
+	g = NewGameState([]Claim{root, a, b, a1, a2, b1}, 2)
+	return g, root, a, b, a1, a2, b1
+}
+
+func TestGameState_Descendants_BreadthFirstOrder(t *testing.T) {
+	g, root, a, b, a1, a2, b1 := twoLevelTree()
+
+	var visited []Claim
+	for claim := range g.Descendants(root) {
+		visited = append(visited, claim)
+	}
+
+	require.Equal(t, []Claim{a, b, a1, a2, b1}, visited,
+		"Descendants must yield every level in full before descending to the next")
+}
+
+func TestGameState_Descendants_StopsEarlyWhenYieldReturnsFalse(t *testing.T) {
+	g, root, a, b, _, _, _ := twoLevelTree()
+
+	var visited []Claim
+	for claim := range g.Descendants(root) {
+		visited = append(visited, claim)
+		if len(visited) == 2 {
+			break
+		}
+	}
+
+	require.Equal(t, []Claim{a, b}, visited, "iteration must stop as soon as the consumer stops ranging")
+}
+
+// TestGameState_Subtree_ClaimsScopedToRootAndDescendants checks that a
+// subtree's Claims() only reports claim and its descendants, in their
+// original contract-submission order.
+func TestGameState_Subtree_ClaimsScopedToRootAndDescendants(t *testing.T) {
+	g, _, a, _, a1, a2, _ := twoLevelTree()
+
+	sub := g.Subtree(a)
+
+	require.Equal(t, []Claim{a, a1, a2}, sub.Claims())
+}
+
+// TestGameState_Subtree_GetParentErrorsOnRootButOtherMethodsReachFullTree
+// documents the subtree view's one asymmetry: GetParent(claim) on the
+// subtree's own root returns ErrClaimNotFound, since claim has no parent
+// within the view, but every other method -- including DefendsParent, which
+// is promoted from the embedded gameState rather than overridden -- still
+// resolves relationships against the full game, not just the subtree.
+func TestGameState_Subtree_GetParentErrorsOnRootButOtherMethodsReachFullTree(t *testing.T) {
+	g, root, a, _, a1, _, _ := twoLevelTree()
+
+	sub := g.Subtree(a)
+
+	_, err := sub.GetParent(a)
+	require.ErrorIs(t, err, ErrClaimNotFound, "the subtree root has no parent within the view")
+
+	parent, err := sub.GetParent(a1)
+	require.NoError(t, err)
+	require.Equal(t, a, parent, "claims below the subtree root still resolve their parent normally")
+
+	require.Equal(t, g.DefendsParent(a), sub.DefendsParent(a),
+		"DefendsParent is inherited from gameState unmodified, so it still answers against root, not ErrClaimNotFound, for the subtree root")
+}
@@ -0,0 +1,283 @@
+package types
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Subscription represents a live GameNotifier event stream. Mirrors the
+// go-ethereum event.Subscription contract: Err reports (at most once) why
+// the subscription ended, and Unsubscribe tears it down.
+type Subscription interface {
+	// Err returns a channel that is closed (after optionally receiving a
+	// single error) when the subscription ends.
+	Err() <-chan error
+	// Unsubscribe stops the subscription from receiving further events. It
+	// is safe to call more than once.
+	Unsubscribe()
+}
+
+// ClaimResolution describes a claim whose CounteredBy transitioned from
+// unset to set, i.e. it was just resolved against.
+type ClaimResolution struct {
+	Claim       Claim
+	CounteredBy common.Address
+}
+
+// Config configures a GameNotifier, mirroring the go-ethereum FilterSystem
+// Config.
+type Config struct {
+	// Timeout bounds how long ApplyUpdate blocks trying to hand an event to
+	// a slow subscriber before giving up and dropping the oldest buffered
+	// event for that subscriber instead.
+	Timeout time.Duration
+	// ChanBuf is the size of the internal per-subscriber event buffer.
+	ChanBuf int
+}
+
+const (
+	defaultTimeout = 5 * time.Second
+	defaultChanBuf = 64
+)
+
+// subscription is the concrete Subscription backing every Subscribe* method:
+// events are buffered internally up to Config.ChanBuf and relayed to the
+// caller's channel by a dedicated goroutine, so a slow consumer can never
+// block ApplyUpdate for longer than Config.Timeout per event.
+type subscription[T any] struct {
+	out  chan<- T
+	buf  chan T
+	err  chan error
+	quit chan struct{}
+	once sync.Once
+	del  func()
+}
+
+func newSubscription[T any](out chan<- T, bufSize int, del func()) *subscription[T] {
+	s := &subscription[T]{
+		out:  out,
+		buf:  make(chan T, bufSize),
+		err:  make(chan error, 1),
+		quit: make(chan struct{}),
+		del:  del,
+	}
+	go s.relay()
+	return s
+}
+
+func (s *subscription[T]) relay() {
+	for {
+		select {
+		case ev := <-s.buf:
+			select {
+			case s.out <- ev:
+			case <-s.quit:
+				return
+			}
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// deliver enqueues ev for relay, drop-oldest if the buffer is full so a slow
+// subscriber never blocks the notifier beyond timeout for this event.
+func (s *subscription[T]) deliver(ev T, timeout time.Duration) {
+	select {
+	case s.buf <- ev:
+		return
+	default:
+	}
+	t := time.NewTimer(timeout)
+	defer t.Stop()
+	select {
+	case s.buf <- ev:
+	case <-t.C:
+		select {
+		case <-s.buf:
+		default:
+		}
+		select {
+		case s.buf <- ev:
+		default:
+		}
+	case <-s.quit:
+	}
+}
+
+func (s *subscription[T]) Err() <-chan error { return s.err }
+
+func (s *subscription[T]) Unsubscribe() {
+	s.once.Do(func() {
+		close(s.quit)
+		s.del()
+		close(s.err)
+	})
+}
+
+// GameNotifier wraps a gameState with an event-driven subscription facility,
+// inspired by the go-ethereum FilterSystem split: ApplyUpdate diffs an
+// incoming claim set against the previously applied one (using the
+// children/claimIDs indices built by NewGameState) and fans the resulting
+// events out to subscribers, so consumers like the challenger loop can react
+// to claim-graph changes instead of polling and diffing two snapshots
+// themselves.
+type GameNotifier struct {
+	cfg Config
+
+	mu        sync.Mutex
+	state     *gameState
+	newClaim  map[*subscription[Claim]]struct{}
+	countered map[*subscription[Claim]]struct{}
+	resolved  map[*subscription[ClaimResolution]]struct{}
+}
+
+// NewGameNotifier creates a GameNotifier seeded with the current state of
+// the game. Zero-valued Config fields fall back to sane defaults.
+func NewGameNotifier(initial *gameState, cfg Config) *GameNotifier {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.ChanBuf <= 0 {
+		cfg.ChanBuf = defaultChanBuf
+	}
+	return &GameNotifier{
+		cfg:       cfg,
+		state:     initial,
+		newClaim:  make(map[*subscription[Claim]]struct{}),
+		countered: make(map[*subscription[Claim]]struct{}),
+		resolved:  make(map[*subscription[ClaimResolution]]struct{}),
+	}
+}
+
+// SubscribeNewClaim delivers every claim added by a subsequent ApplyUpdate.
+func (n *GameNotifier) SubscribeNewClaim(ch chan<- Claim) Subscription {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var sub *subscription[Claim]
+	sub = newSubscription(ch, n.cfg.ChanBuf, func() {
+		n.mu.Lock()
+		delete(n.newClaim, sub)
+		n.mu.Unlock()
+	})
+	n.newClaim[sub] = struct{}{}
+	return sub
+}
+
+// SubscribeCountered delivers a claim the first time it gains a child that
+// disagrees with it (i.e. the claim goes from zero to one or more
+// disagreeing children).
+func (n *GameNotifier) SubscribeCountered(ch chan<- Claim) Subscription {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var sub *subscription[Claim]
+	sub = newSubscription(ch, n.cfg.ChanBuf, func() {
+		n.mu.Lock()
+		delete(n.countered, sub)
+		n.mu.Unlock()
+	})
+	n.countered[sub] = struct{}{}
+	return sub
+}
+
+// SubscribeResolved delivers a ClaimResolution the first time a claim's
+// CounteredBy transitions from unset to set.
+func (n *GameNotifier) SubscribeResolved(ch chan<- ClaimResolution) Subscription {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var sub *subscription[ClaimResolution]
+	sub = newSubscription(ch, n.cfg.ChanBuf, func() {
+		n.mu.Lock()
+		delete(n.resolved, sub)
+		n.mu.Unlock()
+	})
+	n.resolved[sub] = struct{}{}
+	return sub
+}
+
+// ApplyUpdate computes the diff between newClaims and the previously applied
+// claim set and fans out the resulting new-claim, countered and resolved
+// events to subscribers.
+func (n *GameNotifier) ApplyUpdate(newClaims []Claim) {
+	n.mu.Lock()
+	prior := n.state
+	next := NewGameState(newClaims, prior.depth)
+	n.state = next
+	newClaimSubs := make([]*subscription[Claim], 0, len(n.newClaim))
+	for sub := range n.newClaim {
+		newClaimSubs = append(newClaimSubs, sub)
+	}
+	counteredSubs := make([]*subscription[Claim], 0, len(n.countered))
+	for sub := range n.countered {
+		counteredSubs = append(counteredSubs, sub)
+	}
+	resolvedSubs := make([]*subscription[ClaimResolution], 0, len(n.resolved))
+	for sub := range n.resolved {
+		resolvedSubs = append(resolvedSubs, sub)
+	}
+	timeout := n.cfg.Timeout
+	n.mu.Unlock()
+
+	counteredParents := make(map[int]bool)
+	for _, claim := range newClaims {
+		id := computeClaimID(claim)
+		if prior.claimIDs[id] {
+			continue
+		}
+		for _, sub := range newClaimSubs {
+			sub.deliver(claim, timeout)
+		}
+		if claim.IsRoot() {
+			continue
+		}
+		parentIdx := claim.ParentContractIndex
+		if counteredParents[parentIdx] {
+			continue
+		}
+		if parentIdx < 0 || parentIdx >= len(newClaims) {
+			continue
+		}
+		// The parent may itself have been added in this same update (e.g.
+		// first sync after startup, or polling less often than moves arrive),
+		// so it is looked up in newClaims rather than prior.
+		parent := newClaims[parentIdx]
+		if claim.Value == parent.Value || priorChildDisagreed(prior, parentIdx, parent.Value) {
+			continue
+		}
+		counteredParents[parentIdx] = true
+		for _, sub := range counteredSubs {
+			sub.deliver(parent, timeout)
+		}
+	}
+
+	var zeroAddr common.Address
+	for _, claim := range newClaims {
+		id := computeClaimID(claim)
+		priorIdx, ok := prior.claimIndices[id]
+		if !ok || claim.CounteredBy == zeroAddr {
+			continue
+		}
+		if prior.claims[priorIdx].CounteredBy != zeroAddr {
+			continue
+		}
+		res := ClaimResolution{Claim: claim, CounteredBy: claim.CounteredBy}
+		for _, sub := range resolvedSubs {
+			sub.deliver(res, timeout)
+		}
+	}
+}
+
+// priorChildDisagreed reports whether parentIdx, as of prior, already had at
+// least one child whose Value disagreed with parentValue -- i.e. whether
+// parentIdx was already countered before this update (either reported by an
+// earlier ApplyUpdate or already so in the notifier's initial state).
+func priorChildDisagreed(prior *gameState, parentIdx int, parentValue common.Hash) bool {
+	for _, childIdx := range prior.children[parentIdx] {
+		if prior.claims[childIdx].Value != parentValue {
+			return true
+		}
+	}
+	return false
+}
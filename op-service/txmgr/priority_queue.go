@@ -0,0 +1,363 @@
+package txmgr
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ErrDropped is returned on the receipt channel when a queued item is evicted
+// from a full PriorityQueue to make room for a higher-ranked item. Callers
+// receiving ErrDropped may choose to requeue the candidate.
+var ErrDropped = errors.New("txmgr: item dropped from queue to make room for a higher priority send")
+
+// Tier distinguishes the two ranking classes a PriorityQueue maintains.
+// Items in TierPriority are always drained ahead of items in TierBestEffort.
+type Tier int
+
+const (
+	TierBestEffort Tier = iota
+	TierPriority
+)
+
+// PriorityComparator orders two items of the same tier by their ordering
+// key. It should return true if a ranks ahead of (i.e. should be sent
+// before) b, analogous to CandidateComparator{relayParentBlockNumber,
+// candidateHash} in the relay chain dispute-participation queue: ties are
+// typically broken by nonce, then block number, then an id hash to keep the
+// ordering total. K is a separate type parameter from the queue's id type so
+// callers can use a composite key (e.g. a (nonce, blockNumber, hash) struct)
+// without having to cram it into their id type.
+type PriorityComparator[K any] func(a, b K) bool
+
+// queueItem is a single entry waiting to be sent, along with the bookkeeping
+// needed to place it in a heap and report it back to its caller.
+type queueItem[T any, K any] struct {
+	id        T
+	candidate TxCandidate
+	tier      Tier
+	key       K
+	receiptCh chan TxReceipt[T]
+	index     int // heap index, maintained by container/heap
+}
+
+// tierHeap is a container/heap.Interface over queueItems of a single tier,
+// ordered by the supplied comparator: the best-ranked item is popped first
+// via Pop, and the worst-ranked one is located via worst.
+type tierHeap[T any, K any] struct {
+	items []*queueItem[T, K]
+	less  PriorityComparator[K]
+}
+
+func (h tierHeap[T, K]) Len() int { return len(h.items) }
+func (h tierHeap[T, K]) Less(i, j int) bool {
+	return h.less(h.items[i].key, h.items[j].key)
+}
+func (h tierHeap[T, K]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
+func (h *tierHeap[T, K]) Push(x any) {
+	item := x.(*queueItem[T, K])
+	item.index = len(h.items)
+	h.items = append(h.items, item)
+}
+func (h *tierHeap[T, K]) Pop() any {
+	old := h.items
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	h.items = old[:n-1]
+	item.index = -1 // mark as no longer queued, so callers can detect removal
+	return item
+}
+
+// worst returns the index of the lowest-ranked item in the heap (the one
+// evicted first on overflow), found in O(n) since container/heap only
+// maintains a min-heap invariant at the root.
+func (h *tierHeap[T, K]) worst() int {
+	worst := 0
+	for i := 1; i < len(h.items); i++ {
+		if h.less(h.items[worst].key, h.items[i].key) {
+			worst = i
+		}
+	}
+	return worst
+}
+
+// PriorityQueue is a sibling of Queue that sends candidates out of order,
+// draining a bounded priority heap ahead of a bounded best-effort heap
+// whenever a worker slot frees up. Overflowing either heap evicts its
+// worst-ranked pending item and reports it to the caller as ErrDropped via
+// the receipt channel it was submitted with, so the caller can decide
+// whether to requeue it.
+//
+// This gives callers like the batcher or proposer a way to guarantee
+// critical txs (e.g. proposer/output-root submissions) land ahead of
+// best-effort traffic under sustained congestion, without running a second
+// TxManager.
+type PriorityQueue[T any, K any] struct {
+	ctx            context.Context
+	txMgr          TxManager
+	maxPending     uint64
+	priorityCap    int
+	bestEffortCap  int
+	less           PriorityComparator[K]
+	pendingChanged func(tier Tier, depth int)
+
+	mu          sync.Mutex
+	priorityQ   tierHeap[T, K]
+	bestEffortQ tierHeap[T, K]
+
+	receiptWg sync.WaitGroup
+	pending   atomic.Uint64
+	groupLock sync.Mutex
+	groupCtx  context.Context
+	group     *errgroup.Group
+}
+
+// NewPriorityQueue creates a new PriorityQueue, with the following parameters:
+//   - maxPending: max number of in-flight sends at once (0 == no limit)
+//   - priorityCap/bestEffortCap: max number of queued (not yet sent) items
+//     held in each tier's heap before the worst-ranked item is evicted
+//   - less: orders items within a tier by their K-typed key; used to pick
+//     both the next item to drain and the worst item to evict on overflow
+//   - pendingChanged: called whenever an item is enqueued, dequeued, sent or
+//     evicted, with the tier affected and that tier's current queued depth
+func NewPriorityQueue[T any, K any](ctx context.Context, txMgr TxManager, maxPending uint64, priorityCap, bestEffortCap int, less PriorityComparator[K], pendingChanged func(tier Tier, depth int)) *PriorityQueue[T, K] {
+	if maxPending > math.MaxInt {
+		// ensure we don't overflow as errgroup only accepts int; in reality this will never be an issue
+		maxPending = math.MaxInt
+	}
+	return &PriorityQueue[T, K]{
+		ctx:            ctx,
+		txMgr:          txMgr,
+		maxPending:     maxPending,
+		priorityCap:    priorityCap,
+		bestEffortCap:  bestEffortCap,
+		less:           less,
+		pendingChanged: pendingChanged,
+		priorityQ:      tierHeap[T, K]{less: less},
+		bestEffortQ:    tierHeap[T, K]{less: less},
+	}
+}
+
+// Wait waits for all pending sends to complete (or fail).
+func (q *PriorityQueue[T, K]) Wait() {
+	q.receiptWg.Wait()
+	if q.group == nil {
+		return
+	}
+	_ = q.group.Wait()
+}
+
+// SendWithPriority enqueues candidate with the given priority tier and ordering key,
+// blocking until a worker slot is available to drain it. The actual tx
+// sending is non-blocking, with the receipt (or an ErrDropped eviction)
+// returned on the provided receipt channel.
+func (q *PriorityQueue[T, K]) SendWithPriority(id T, candidate TxCandidate, prio Tier, key K, receiptCh chan TxReceipt[T]) {
+	q.enqueue(id, candidate, prio, key, receiptCh)
+	group, ctx := q.groupContext()
+	group.Go(func() error {
+		return q.drainLoop(ctx)
+	})
+}
+
+// TrySendWithPriority enqueues candidate like SendWithPriority, but only if
+// a worker slot is available to start draining it. Mirrors Queue.TrySend's
+// contract: returns false if there is no room, in which case candidate was
+// not queued (unless a concurrently running worker happened to drain it in
+// the same instant, which is treated as success).
+func (q *PriorityQueue[T, K]) TrySendWithPriority(id T, candidate TxCandidate, prio Tier, key K, receiptCh chan TxReceipt[T]) bool {
+	item := q.enqueue(id, candidate, prio, key, receiptCh)
+	if item == nil {
+		// item was itself the worst-ranked entry in a full heap and was
+		// dropped on arrival; enqueue already reported ErrDropped for it.
+		return false
+	}
+	group, ctx := q.groupContext()
+	started := group.TryGo(func() error {
+		return q.drainLoop(ctx)
+	})
+	if !started && !q.cancel(item) {
+		// a concurrently running worker already popped item for sending
+		return true
+	}
+	return started
+}
+
+// Send is a thin wrapper around SendWithPriority that enqueues candidate as
+// best-effort work, ranked within that tier by key via the comparator.
+func (q *PriorityQueue[T, K]) Send(id T, candidate TxCandidate, key K, receiptCh chan TxReceipt[T]) {
+	q.SendWithPriority(id, candidate, TierBestEffort, key, receiptCh)
+}
+
+// TrySend is a thin wrapper around TrySendWithPriority that enqueues
+// candidate as best-effort work, ranked within that tier by key.
+func (q *PriorityQueue[T, K]) TrySend(id T, candidate TxCandidate, key K, receiptCh chan TxReceipt[T]) bool {
+	return q.TrySendWithPriority(id, candidate, TierBestEffort, key, receiptCh)
+}
+
+// PendingPriority returns the number of items currently queued (not yet
+// sent) in the priority tier.
+func (q *PriorityQueue[T, K]) PendingPriority() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.priorityQ.Len()
+}
+
+// PendingBestEffort returns the number of items currently queued (not yet
+// sent) in the best-effort tier.
+func (q *PriorityQueue[T, K]) PendingBestEffort() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.bestEffortQ.Len()
+}
+
+// enqueue places item in the appropriate tier's heap, evicting the
+// worst-ranked item in that tier if it is at capacity -- unless the new
+// item is itself no better than the current worst, in which case the new
+// item is the one dropped instead, so a run of poorly-ranked newcomers can
+// never displace genuinely better items already queued. Returns the item if
+// it was placed in the heap, or nil if it was dropped on arrival (in which
+// case ErrDropped has already been reported on receiptCh).
+func (q *PriorityQueue[T, K]) enqueue(id T, candidate TxCandidate, tier Tier, key K, receiptCh chan TxReceipt[T]) *queueItem[T, K] {
+	q.receiptWg.Add(1)
+	item := &queueItem[T, K]{id: id, candidate: candidate, tier: tier, key: key, receiptCh: receiptCh}
+
+	q.mu.Lock()
+	h := q.heapFor(tier)
+	capacity := q.capFor(tier)
+	if capacity > 0 && h.Len() >= capacity {
+		worstIdx := h.worst()
+		worst := h.items[worstIdx]
+		if !h.less(item.key, worst.key) {
+			// the newcomer doesn't outrank the current worst; drop the
+			// newcomer rather than evicting something better than it.
+			q.mu.Unlock()
+			q.notifyDropped(item)
+			return nil
+		}
+		heap.Remove(h, worstIdx)
+		q.notifyDropped(worst)
+	}
+	heap.Push(h, item)
+	depth := h.Len()
+	q.mu.Unlock()
+
+	if q.pendingChanged != nil {
+		q.pendingChanged(tier, depth)
+	}
+	return item
+}
+
+// cancel removes item from its tier's heap if it is still queued there. Used
+// by TrySendWithPriority to honor the "not queued" contract when no worker
+// slot is available to drain it. If a concurrently running worker already
+// popped item (item.index == -1) for sending, cancel is a no-op and the send
+// proceeds as normal. Returns true if item was removed.
+func (q *PriorityQueue[T, K]) cancel(item *queueItem[T, K]) bool {
+	q.mu.Lock()
+	h := q.heapFor(item.tier)
+	if item.index < 0 || item.index >= h.Len() || h.items[item.index] != item {
+		q.mu.Unlock()
+		return false
+	}
+	heap.Remove(h, item.index)
+	depth := h.Len()
+	q.mu.Unlock()
+
+	if q.pendingChanged != nil {
+		q.pendingChanged(item.tier, depth)
+	}
+	q.receiptWg.Done()
+	return true
+}
+
+func (q *PriorityQueue[T, K]) heapFor(tier Tier) *tierHeap[T, K] {
+	if tier == TierPriority {
+		return &q.priorityQ
+	}
+	return &q.bestEffortQ
+}
+
+func (q *PriorityQueue[T, K]) capFor(tier Tier) int {
+	if tier == TierPriority {
+		return q.priorityCap
+	}
+	return q.bestEffortCap
+}
+
+// notifyDropped reports an evicted item back to its caller and releases the
+// receiptWg slot it was holding.
+func (q *PriorityQueue[T, K]) notifyDropped(item *queueItem[T, K]) {
+	go func() {
+		item.receiptCh <- TxReceipt[T]{ID: item.id, Err: ErrDropped}
+		q.receiptWg.Done()
+	}()
+}
+
+// drainLoop pops the next item to send -- priority tier first, falling back
+// to best-effort -- and sends it. Returns nil without sending if both tiers
+// were emptied by a concurrent drain before this worker got to pop.
+func (q *PriorityQueue[T, K]) drainLoop(ctx context.Context) error {
+	item, ok := q.pop()
+	if !ok {
+		return nil
+	}
+	return q.sendItem(ctx, item)
+}
+
+func (q *PriorityQueue[T, K]) pop() (*queueItem[T, K], bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var h *tierHeap[T, K]
+	if q.priorityQ.Len() > 0 {
+		h = &q.priorityQ
+	} else if q.bestEffortQ.Len() > 0 {
+		h = &q.bestEffortQ
+	} else {
+		return nil, false
+	}
+	item := heap.Pop(h).(*queueItem[T, K])
+	if q.pendingChanged != nil {
+		defer q.pendingChanged(item.tier, h.Len())
+	}
+	return item, true
+}
+
+func (q *PriorityQueue[T, K]) sendItem(ctx context.Context, item *queueItem[T, K]) error {
+	q.pending.Add(1)
+	defer q.pending.Add(^uint64(0)) // -1
+	receipt, err := q.txMgr.Send(ctx, item.candidate)
+	go func() {
+		item.receiptCh <- TxReceipt[T]{ID: item.id, Receipt: receipt, Err: err}
+		q.receiptWg.Done()
+	}()
+	return err
+}
+
+// groupContext creates a new Context that is canceled if either the given
+// context is Done, or the group context is canceled. If the group context
+// doesn't exist or has already been canceled, a new one is created after
+// waiting for existing group threads to complete.
+func (q *PriorityQueue[T, K]) groupContext() (*errgroup.Group, context.Context) {
+	q.groupLock.Lock()
+	defer q.groupLock.Unlock()
+	if q.groupCtx == nil || q.groupCtx.Err() != nil {
+		if q.group != nil {
+			_ = q.group.Wait()
+		}
+		q.group, q.groupCtx = errgroup.WithContext(q.ctx)
+		if q.maxPending > 0 {
+			q.group.SetLimit(int(q.maxPending))
+		}
+	}
+	return q.group, q.groupCtx
+}
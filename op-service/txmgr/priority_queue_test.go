@@ -0,0 +1,101 @@
+package txmgr
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func lessInt(a, b int) bool { return a < b }
+
+func TestPriorityQueue_EnqueueDropsWorseNewcomerInsteadOfDisplacingBetter(t *testing.T) {
+	q := NewPriorityQueue[int, int](context.Background(), nil, 0, 0, 2, lessInt, nil)
+
+	ch1 := make(chan TxReceipt[int], 1)
+	ch2 := make(chan TxReceipt[int], 1)
+	chNew := make(chan TxReceipt[int], 1)
+
+	// key 1 and 2 are both better (lower) than the newcomer, key 10.
+	require.NotNil(t, q.enqueue(1, TxCandidate{}, TierBestEffort, 1, ch1))
+	require.NotNil(t, q.enqueue(2, TxCandidate{}, TierBestEffort, 2, ch2))
+
+	item := q.enqueue(10, TxCandidate{}, TierBestEffort, 10, chNew)
+	require.Nil(t, item, "worse-than-everything newcomer should be dropped on arrival")
+
+	receipt := <-chNew
+	require.ErrorIs(t, receipt.Err, ErrDropped)
+
+	require.Equal(t, 2, q.PendingBestEffort(), "the two better, already-queued items must survive")
+	select {
+	case <-ch1:
+		t.Fatal("better-ranked item 1 should not have been evicted")
+	case <-ch2:
+		t.Fatal("better-ranked item 2 should not have been evicted")
+	default:
+	}
+}
+
+func TestPriorityQueue_EnqueueEvictsWorstWhenNewcomerRanksBetter(t *testing.T) {
+	q := NewPriorityQueue[int, int](context.Background(), nil, 0, 0, 1, lessInt, nil)
+
+	chWorst := make(chan TxReceipt[int], 1)
+	chBetter := make(chan TxReceipt[int], 1)
+
+	require.NotNil(t, q.enqueue(5, TxCandidate{}, TierBestEffort, 5, chWorst))
+
+	item := q.enqueue(1, TxCandidate{}, TierBestEffort, 1, chBetter)
+	require.NotNil(t, item, "better-ranked newcomer should displace the worse existing item")
+
+	receipt := <-chWorst
+	require.ErrorIs(t, receipt.Err, ErrDropped)
+	require.Equal(t, 1, q.PendingBestEffort())
+}
+
+// TestPriorityQueue_EndToEnd_DrainsPriorityBeforeBestEffort exercises the
+// public SendWithPriority path against a real (fake) TxManager, with a
+// single worker slot so the tier ordering has to come from pop() rather
+// than sheer concurrency: once the in-flight send completes and the slot
+// frees up, the queued priority-tier item must be sent before the
+// queued best-effort one.
+func TestPriorityQueue_EndToEnd_DrainsPriorityBeforeBestEffort(t *testing.T) {
+	gate := make(chan struct{})
+	var mu sync.Mutex
+	var sendOrder []uint64
+	txMgr := &fakeTxManager{sendFn: func(ctx context.Context, candidate TxCandidate) (*types.Receipt, error) {
+		if candidate.GasLimit == 1 {
+			<-gate // hold the only worker slot until items 2 and 3 have queued up
+		}
+		mu.Lock()
+		sendOrder = append(sendOrder, candidate.GasLimit)
+		mu.Unlock()
+		return &types.Receipt{}, nil
+	}}
+
+	q := NewPriorityQueue[int, int](context.Background(), txMgr, 1, 10, 10, lessInt, nil)
+
+	ch1 := make(chan TxReceipt[int], 1)
+	ch2 := make(chan TxReceipt[int], 1)
+	ch3 := make(chan TxReceipt[int], 1)
+
+	q.SendWithPriority(1, TxCandidate{GasLimit: 1}, TierBestEffort, 1, ch1)
+	go q.SendWithPriority(2, TxCandidate{GasLimit: 2}, TierBestEffort, 2, ch2)
+	go q.SendWithPriority(3, TxCandidate{GasLimit: 3}, TierPriority, 3, ch3)
+
+	require.Eventually(t, func() bool {
+		return q.PendingBestEffort() == 1 && q.PendingPriority() == 1
+	}, time.Second, time.Millisecond, "items 2 and 3 must be queued while item 1 occupies the only worker slot")
+
+	close(gate)
+
+	require.NoError(t, (<-ch1).Err)
+	require.NoError(t, (<-ch3).Err)
+	require.NoError(t, (<-ch2).Err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []uint64{1, 3, 2}, sendOrder, "the priority-tier item must drain ahead of the best-effort item once a slot frees up")
+}
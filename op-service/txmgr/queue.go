@@ -2,7 +2,9 @@ package txmgr
 
 import (
 	"context"
+	"fmt"
 	"math"
+	"strconv"
 	"sync"
 	"sync/atomic"
 
@@ -19,32 +21,138 @@ type TxReceipt[T any] struct {
 	Err error
 }
 
+// queuedSend is a single Send/TrySend call waiting to be drained by its
+// key's pump goroutine.
+type queuedSend[T any] struct {
+	seq       uint64
+	id        T
+	candidate TxCandidate
+	receiptCh chan TxReceipt[T]
+	// sendCtx, if non-nil, scopes the actual txMgr.Send call for this job in
+	// place of the pump's own (queue-lifetime) context. Set by Recover so a
+	// caller-supplied ctx governs cancellation of resubmitted sends.
+	sendCtx context.Context
+}
+
+// keyQueue is the FIFO of queuedSends for a single key. While running is
+// true, a pump goroutine owns draining jobs in order; it clears running and
+// exits once the queue is empty, releasing its errgroup slot. jobs and
+// running are guarded by the owning Queue's keyMu, not a lock of their own,
+// so a key can be added to and removed from Queue.keyQueues atomically with
+// its own state changes.
+type keyQueue[T any] struct {
+	jobs    []*queuedSend[T]
+	running bool
+}
+
 type Queue[T any] struct {
-	ctx            context.Context
-	txMgr          TxManager
-	maxPending     uint64
-	pendingChanged func(uint64)
-	receiptWg      sync.WaitGroup
-	pending        atomic.Uint64
-	groupLock      sync.Mutex
-	groupCtx       context.Context
-	group          *errgroup.Group
+	ctx             context.Context
+	txMgr           TxManager
+	maxPending      uint64
+	maxQueuedPerKey uint64
+	pendingChanged  func(key string, pending uint64)
+	keyFor          func(TxCandidate) string
+	receiptWg       sync.WaitGroup
+	pending         atomic.Uint64
+	groupLock       sync.Mutex
+	groupCtx        context.Context
+	group           *errgroup.Group
+
+	anonKeySeq atomic.Uint64
+	keyMu      sync.Mutex
+	keyQueues  map[string]*keyQueue[T]
+
+	// store and seq are only set when persistence is enabled via
+	// NewQueueWithStore. recovered holds records replayed from store at
+	// construction time, awaiting a Recover call to resubmit them.
+	store     QueueStore[T]
+	seq       atomic.Uint64
+	recovered []record[T]
 }
 
 // NewQueue creates a new transaction sending Queue, with the following parameters:
 //   - maxPending: max number of pending txs at once (0 == no limit)
-//   - pendingChanged: called whenever a tx send starts or finishes. The
-//     number of currently pending txs is passed as a parameter.
-func NewQueue[T any](ctx context.Context, txMgr TxManager, maxPending uint64, pendingChanged func(uint64)) *Queue[T] {
+//   - maxQueuedPerKey: max number of txs TrySend will hold queued for a
+//     single key once that key already has a send in flight (0 == no
+//     limit). Only bounds TrySend: Send always queues regardless, matching
+//     its existing blocking/always-accepts contract. Without this cap, a
+//     single hot key can grow an unbounded backlog via TrySend even though
+//     maxPending is meant to bound total outstanding work -- TrySend only
+//     gates a key's *first* in-flight send, since every subsequent send for
+//     that key is satisfied by the same already-running pump rather than
+//     consuming a fresh errgroup slot.
+//   - pendingChanged: called whenever a tx send starts or finishes, with the
+//     key (see keyFor) of the tx responsible and the number of currently
+//     pending txs across all keys
+//   - keyFor: derives a serialization key from a candidate. Candidates
+//     sharing a key are sent strictly FIFO (one at a time, in Send/TrySend
+//     call order); candidates with different keys run in parallel up to
+//     maxPending. If nil, every candidate gets its own unique key, which
+//     reproduces the old fully-parallel behavior.
+func NewQueue[T any](ctx context.Context, txMgr TxManager, maxPending, maxQueuedPerKey uint64, pendingChanged func(key string, pending uint64), keyFor func(TxCandidate) string) *Queue[T] {
 	if maxPending > math.MaxInt {
 		// ensure we don't overflow as errgroup only accepts int; in reality this will never be an issue
 		maxPending = math.MaxInt
 	}
-	return &Queue[T]{
-		ctx:            ctx,
-		txMgr:          txMgr,
-		maxPending:     maxPending,
-		pendingChanged: pendingChanged,
+	q := &Queue[T]{
+		ctx:             ctx,
+		txMgr:           txMgr,
+		maxPending:      maxPending,
+		maxQueuedPerKey: maxQueuedPerKey,
+		pendingChanged:  pendingChanged,
+		keyQueues:       make(map[string]*keyQueue[T]),
+	}
+	if keyFor == nil {
+		keyFor = func(TxCandidate) string {
+			return strconv.FormatUint(q.anonKeySeq.Add(1), 10)
+		}
+	}
+	q.keyFor = keyFor
+	return q
+}
+
+// NewQueueWithStore creates a new Queue like NewQueue, but additionally
+// persists every candidate to store before sending it and deletes the
+// record once its receipt has been delivered, so in-flight candidates
+// survive a process restart.
+//
+// Any records left over from a prior process are loaded (but not yet
+// resubmitted) from store; call Recover after construction to resume them
+// once the caller is ready to supply receipt channels for them.
+func NewQueueWithStore[T any](ctx context.Context, txMgr TxManager, maxPending, maxQueuedPerKey uint64, pendingChanged func(key string, pending uint64), keyFor func(TxCandidate) string, store QueueStore[T]) (*Queue[T], error) {
+	q := NewQueue[T](ctx, txMgr, maxPending, maxQueuedPerKey, pendingChanged, keyFor)
+	q.store = store
+	records, err := store.Iterate()
+	if err != nil {
+		return nil, fmt.Errorf("replaying queue store: %w", err)
+	}
+	var maxSeq uint64
+	for _, rec := range records {
+		if rec.Seq > maxSeq {
+			maxSeq = rec.Seq
+		}
+	}
+	q.seq.Store(maxSeq)
+	q.recovered = records
+	return q, nil
+}
+
+// Recover resubmits every record left over from a prior process, in
+// original submission order, re-attaching a receipt channel obtained from
+// handler for each one. Because TxCandidate only carries calldata/value/gas
+// hints rather than a signature or nonce, replay is idempotent: the
+// underlying TxManager re-prices and re-signs each candidate as if it were
+// newly submitted.
+//
+// ctx scopes the resubmitted sends themselves (e.g. a caller-chosen timeout
+// for recovery), separate from the queue's own lifetime context.
+func (q *Queue[T]) Recover(ctx context.Context, handler func(id T, candidate TxCandidate) chan TxReceipt[T]) {
+	recovered := q.recovered
+	q.recovered = nil
+	for _, rec := range recovered {
+		receiptCh := handler(rec.ID, rec.Candidate)
+		key := q.keyFor(rec.Candidate)
+		q.enqueue(key, &queuedSend[T]{seq: rec.Seq, id: rec.ID, candidate: rec.Candidate, receiptCh: receiptCh, sendCtx: ctx}, false)
 	}
 }
 
@@ -57,17 +165,32 @@ func (q *Queue[T]) Wait() {
 	_ = q.group.Wait()
 }
 
+// PendingByKey returns the number of txs currently queued (submitted via
+// Send/TrySend but not yet sent) per key. Keys whose queue has fully
+// drained are not included.
+func (q *Queue[T]) PendingByKey() map[string]uint64 {
+	q.keyMu.Lock()
+	defer q.keyMu.Unlock()
+	out := make(map[string]uint64, len(q.keyQueues))
+	for key, kq := range q.keyQueues {
+		out[key] = uint64(len(kq.jobs))
+	}
+	return out
+}
+
 // Send will wait until the number of pending txs is below the max pending,
 // and then send the next tx.
 //
 // The actual tx sending is non-blocking, with the receipt returned on the
 // provided receipt channel.
 func (q *Queue[T]) Send(id T, candidate TxCandidate, receiptCh chan TxReceipt[T]) {
-	q.receiptWg.Add(1)
-	group, ctx := q.groupContext()
-	group.Go(func() error {
-		return q.sendTx(ctx, id, candidate, receiptCh)
-	})
+	key := q.keyFor(candidate)
+	seq := q.seq.Add(1)
+	if err := q.persist(seq, id, candidate); err != nil {
+		receiptCh <- TxReceipt[T]{ID: id, Err: fmt.Errorf("persisting queued tx: %w", err)}
+		return
+	}
+	q.enqueue(key, &queuedSend[T]{seq: seq, id: id, candidate: candidate, receiptCh: receiptCh}, false)
 }
 
 // TrySend sends the next tx, but only if the number of pending txs is below the
@@ -79,22 +202,164 @@ func (q *Queue[T]) Send(id T, candidate TxCandidate, receiptCh chan TxReceipt[T]
 // The actual tx sending is non-blocking, with the receipt returned on the
 // provided receipt channel.
 func (q *Queue[T]) TrySend(id T, candidate TxCandidate, receiptCh chan TxReceipt[T]) bool {
+	key := q.keyFor(candidate)
+	seq := q.seq.Add(1)
+	if err := q.persist(seq, id, candidate); err != nil {
+		receiptCh <- TxReceipt[T]{ID: id, Err: fmt.Errorf("persisting queued tx: %w", err)}
+		return false
+	}
+	started := q.enqueue(key, &queuedSend[T]{seq: seq, id: id, candidate: candidate, receiptCh: receiptCh}, true)
+	if !started {
+		q.forget(seq)
+	}
+	return started
+}
+
+// enqueue appends job to key's FIFO queue. If the key is currently idle, a
+// pump goroutine is started to drain it (and every later job for that key,
+// in submission order) until the queue empties. If try is true
+// and the key is idle, starting the pump is attempted via TryGo and enqueue
+// returns false without queuing the job if there is no room in the global
+// errgroup; if try is false, Go blocks until room is available.
+//
+// If try is true and the key already has a pump running, enqueue also
+// enforces maxQueuedPerKey: once that many jobs are already waiting behind
+// the in-flight one, the job is rejected (not queued) rather than growing
+// the key's backlog without bound. try=false (Send) is never bounded this
+// way, matching its existing always-queues contract.
+func (q *Queue[T]) enqueue(key string, job *queuedSend[T], try bool) bool {
+	q.keyMu.Lock()
+	kq, ok := q.keyQueues[key]
+	if !ok {
+		kq = &keyQueue[T]{}
+		q.keyQueues[key] = kq
+	}
+	if kq.running {
+		if try && q.maxQueuedPerKey > 0 && uint64(len(kq.jobs)) >= q.maxQueuedPerKey {
+			q.keyMu.Unlock()
+			return false
+		}
+		// a pump is already draining this key; it will pick up our job in turn
+		kq.jobs = append(kq.jobs, job)
+		q.keyMu.Unlock()
+		q.receiptWg.Add(1)
+		return true
+	}
+	kq.running = true
+	kq.jobs = append(kq.jobs, job)
+	q.keyMu.Unlock()
+
 	q.receiptWg.Add(1)
 	group, ctx := q.groupContext()
-	started := group.TryGo(func() error {
-		return q.sendTx(ctx, id, candidate, receiptCh)
-	})
+	started := true
+	if try {
+		started = group.TryGo(func() error {
+			return q.pumpKey(ctx, key, kq)
+		})
+	} else {
+		group.Go(func() error {
+			return q.pumpKey(ctx, key, kq)
+		})
+	}
 	if !started {
-		// send didn't start so receipt will never be available
+		q.keyMu.Lock()
+		// Remove only our own job: other Send/TrySend calls for this key may
+		// have raced in above (seeing kq.running already true) and been told
+		// `true`, appending their own jobs alongside ours.
+		for i, j := range kq.jobs {
+			if j == job {
+				kq.jobs = append(kq.jobs[:i], kq.jobs[i+1:]...)
+				break
+			}
+		}
+		remaining := len(kq.jobs) > 0
+		if !remaining {
+			kq.running = false
+			delete(q.keyQueues, key)
+		}
+		q.keyMu.Unlock()
 		q.receiptWg.Done()
+		if remaining {
+			// Those other callers already got `true` back, so their jobs must
+			// still be drained. Retry the pump start from a fresh goroutine so
+			// this (non-blocking) TrySend call itself never blocks on a free
+			// errgroup slot.
+			go func() {
+				group.Go(func() error {
+					return q.pumpKey(ctx, key, kq)
+				})
+			}()
+		}
 	}
 	return started
 }
 
-func (q *Queue[T]) sendTx(ctx context.Context, id T, candidate TxCandidate, receiptCh chan TxReceipt[T]) error {
-	q.pendingChanged(q.pending.Add(1))
+// pumpKey drains key's queue one job at a time, in FIFO order, so that
+// candidates sharing a key are always sent strictly sequentially. It exits
+// (releasing its errgroup slot) once the queue is empty, removing key's
+// entry from keyQueues so idle keys don't accumulate forever; a later
+// enqueue call will allocate a fresh entry and pump if more work arrives.
+func (q *Queue[T]) pumpKey(ctx context.Context, key string, kq *keyQueue[T]) error {
+	for {
+		q.keyMu.Lock()
+		if len(kq.jobs) == 0 {
+			kq.running = false
+			if q.keyQueues[key] == kq {
+				delete(q.keyQueues, key)
+			}
+			q.keyMu.Unlock()
+			return nil
+		}
+		job := kq.jobs[0]
+		kq.jobs = kq.jobs[1:]
+		q.keyMu.Unlock()
+
+		sendCtx := ctx
+		cancelMerge := func() {}
+		if job.sendCtx != nil {
+			// Merge rather than replace: the send must still observe the
+			// queue's own shutdown (e.g. Queue.ctx being canceled), in
+			// addition to whatever scope the caller gave this job via
+			// Recover.
+			sendCtx, cancelMerge = mergeContexts(ctx, job.sendCtx)
+		}
+		// Errors are reported on the job's own receipt channel; keep draining
+		// the rest of this key's queue regardless.
+		_ = q.sendTx(sendCtx, key, job.seq, job.id, job.candidate, job.receiptCh)
+		cancelMerge()
+	}
+}
+
+// mergeContexts returns a context that is canceled as soon as either a or b
+// is done. It carries a's values but not b's. The returned cancel func must
+// be called once the merged context is no longer needed, to release the
+// goroutine watching b.
+func mergeContexts(a, b context.Context) (context.Context, context.CancelFunc) {
+	merged, cancel := context.WithCancel(a)
+	if b.Err() != nil {
+		// b is already done; cancel synchronously instead of racing a
+		// goroutine against the caller's very next use of merged.
+		cancel()
+		return merged, cancel
+	}
+	stop := make(chan struct{})
+	go func() {
+		select {
+		case <-b.Done():
+			cancel()
+		case <-stop:
+		}
+	}()
+	return merged, func() {
+		close(stop)
+		cancel()
+	}
+}
+
+func (q *Queue[T]) sendTx(ctx context.Context, key string, seq uint64, id T, candidate TxCandidate, receiptCh chan TxReceipt[T]) error {
+	q.pendingChanged(key, q.pending.Add(1))
 	defer func() {
-		q.pendingChanged(q.pending.Add(^uint64(0))) // -1
+		q.pendingChanged(key, q.pending.Add(^uint64(0))) // -1
 	}()
 	receipt, err := q.txMgr.Send(ctx, candidate)
 	go func() {
@@ -104,11 +369,32 @@ func (q *Queue[T]) sendTx(ctx context.Context, id T, candidate TxCandidate, rece
 			Receipt: receipt,
 			Err:     err,
 		}
+		q.forget(seq)
 		q.receiptWg.Done()
 	}()
 	return err
 }
 
+// persist durably records seq/id/candidate if persistence is enabled, so it
+// can be replayed by Recover after a crash. No-op if the queue was created
+// without a QueueStore.
+func (q *Queue[T]) persist(seq uint64, id T, candidate TxCandidate) error {
+	if q.store == nil {
+		return nil
+	}
+	return q.store.Put(seq, id, candidate)
+}
+
+// forget removes a persisted record once its receipt has been delivered.
+// Best-effort: a record that fails to delete will simply be replayed (and
+// harmlessly re-priced/re-signed) on the next restart.
+func (q *Queue[T]) forget(seq uint64) {
+	if q.store == nil {
+		return
+	}
+	_ = q.store.Delete(seq)
+}
+
 // mergeWithGroupContext creates a new Context that is canceled if either the given context is
 // Done, or the group context is canceled. The returned CancelFunc should be called once finished.
 //
@@ -0,0 +1,113 @@
+package txmgr
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueue_EnqueueCapsBacklogPerKeyForTrySend(t *testing.T) {
+	q := NewQueue[int](context.Background(), nil, 0, 2, func(string, uint64) {}, nil)
+
+	const key = "same-signer"
+	kq := &keyQueue[int]{running: true}
+	q.keyQueues[key] = kq
+	kq.jobs = append(kq.jobs,
+		&queuedSend[int]{id: 1, receiptCh: make(chan TxReceipt[int], 1)},
+		&queuedSend[int]{id: 2, receiptCh: make(chan TxReceipt[int], 1)},
+	)
+
+	accepted := q.enqueue(key, &queuedSend[int]{id: 3, receiptCh: make(chan TxReceipt[int], 1)}, true)
+	require.False(t, accepted, "TrySend should refuse once maxQueuedPerKey jobs are already backed up behind the in-flight one")
+	require.Len(t, kq.jobs, 2, "the rejected job must not be appended to the backlog")
+}
+
+func TestQueue_EnqueueSendIsNeverCappedPerKey(t *testing.T) {
+	q := NewQueue[int](context.Background(), nil, 0, 1, func(string, uint64) {}, nil)
+
+	const key = "same-signer"
+	kq := &keyQueue[int]{running: true}
+	q.keyQueues[key] = kq
+	kq.jobs = append(kq.jobs, &queuedSend[int]{id: 1, receiptCh: make(chan TxReceipt[int], 1)})
+
+	accepted := q.enqueue(key, &queuedSend[int]{id: 2, receiptCh: make(chan TxReceipt[int], 1)}, false)
+	require.True(t, accepted, "Send must always queue, regardless of maxQueuedPerKey")
+	require.Len(t, kq.jobs, 2)
+}
+
+func TestQueue_PersistsBeforeSendAndForgetsAfterReceiptDelivered(t *testing.T) {
+	store := newFakeQueueStore[int]()
+	sentSawPersisted := make(chan bool, 1)
+	txMgr := &fakeTxManager{sendFn: func(ctx context.Context, candidate TxCandidate) (*types.Receipt, error) {
+		sentSawPersisted <- store.has(1)
+		return &types.Receipt{}, nil
+	}}
+	q, err := NewQueueWithStore[int](context.Background(), txMgr, 0, 0, func(string, uint64) {}, nil, store)
+	require.NoError(t, err)
+
+	receiptCh := make(chan TxReceipt[int], 1)
+	q.Send(10, TxCandidate{}, receiptCh)
+
+	require.True(t, <-sentSawPersisted, "the record must be persisted before txMgr.Send is called")
+
+	receipt := <-receiptCh
+	require.NoError(t, receipt.Err)
+	q.Wait()
+
+	require.Equal(t, 0, store.len(), "the record must be forgotten once its receipt has been delivered")
+	require.Equal(t, []string{"put", "delete"}, store.calls)
+}
+
+func TestQueue_RecoverReplaysInSeqOrderAndScopesSendToItsOwnCtx(t *testing.T) {
+	store := newFakeQueueStore[int]()
+	// GasLimit carries the record's id so sendFn, which only sees the
+	// candidate, can identify which record each Send call belongs to.
+	require.NoError(t, store.Put(2, 20, TxCandidate{GasLimit: 20}))
+	require.NoError(t, store.Put(1, 10, TxCandidate{GasLimit: 10}))
+	require.NoError(t, store.Put(3, 30, TxCandidate{GasLimit: 30}))
+
+	recoverCtx, cancel := context.WithCancel(context.Background())
+	cancel() // already-canceled, so a resubmitted Send must observe it via sendCtx
+
+	var mu sync.Mutex
+	var sendOrder []uint64
+	allCanceled := true
+	txMgr := &fakeTxManager{sendFn: func(ctx context.Context, candidate TxCandidate) (*types.Receipt, error) {
+		mu.Lock()
+		sendOrder = append(sendOrder, candidate.GasLimit)
+		allCanceled = allCanceled && ctx.Err() != nil
+		mu.Unlock()
+		return &types.Receipt{}, nil
+	}}
+
+	// Force all three onto one key so they serialize FIFO; replay order is
+	// otherwise only guaranteed per key, not globally.
+	sameKey := func(TxCandidate) string { return "all-one-key" }
+	q, err := NewQueueWithStore[int](context.Background(), txMgr, 0, 0, func(string, uint64) {}, sameKey, store)
+	require.NoError(t, err)
+
+	var remaining int32 = 3
+	done := make(chan struct{})
+	q.Recover(recoverCtx, func(id int, candidate TxCandidate) chan TxReceipt[int] {
+		ch := make(chan TxReceipt[int], 1)
+		go func() {
+			<-ch
+			if atomic.AddInt32(&remaining, -1) == 0 {
+				close(done)
+			}
+		}()
+		return ch
+	})
+
+	<-done
+	q.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []uint64{10, 20, 30}, sendOrder, "replay must resubmit in ascending seq (original submission) order")
+	require.True(t, allCanceled, "resubmitted sends must be scoped to the ctx passed into Recover, not the queue's own ctx")
+}
@@ -0,0 +1,132 @@
+package txmgr
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// record is the on-disk representation of a single queued item, persisted
+// before it is sent so it can be replayed if the process crashes between
+// Send and receipt delivery.
+type record[T any] struct {
+	Seq       uint64
+	ID        T
+	Candidate TxCandidate
+}
+
+// QueueStore persists in-flight queue items so they survive a process
+// restart. Implementations must be safe for concurrent use.
+type QueueStore[T any] interface {
+	// Put durably records that seq/id/candidate is in flight.
+	Put(seq uint64, id T, candidate TxCandidate) error
+	// Delete removes the record for seq once its receipt has been delivered.
+	Delete(seq uint64) error
+	// Iterate returns every persisted record, in ascending seq (i.e.
+	// original submission) order.
+	Iterate() ([]record[T], error)
+}
+
+const (
+	recordExt = ".rec"
+	lockName  = "LOCK"
+)
+
+// FileQueueStore is the default QueueStore, persisting one gob-encoded file
+// per record in a directory. Each record is written to a temp file and
+// renamed into place so a crash mid-write never leaves a partial record
+// behind for Iterate to trip over.
+type FileQueueStore[T any] struct {
+	dir  string
+	lock *os.File
+}
+
+// NewFileQueueStore opens (creating if necessary) a directory-backed
+// QueueStore rooted at dir, taking an exclusive lock on it so a second
+// process (e.g. an old instance still shutting down) can't open the same
+// dir and race with this one on Put/Delete/Iterate. Call Close to release
+// the lock. If a prior process holding the lock crashed without calling
+// Close, the stale LOCK file under dir must be removed manually before the
+// store can be reopened.
+func NewFileQueueStore[T any](dir string) (*FileQueueStore[T], error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("creating queue store dir: %w", err)
+	}
+	lock, err := os.OpenFile(filepath.Join(dir, lockName), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		if os.IsExist(err) {
+			return nil, fmt.Errorf("queue store %s is already locked by another process (remove %s if that process has exited)", dir, lockName)
+		}
+		return nil, fmt.Errorf("locking queue store dir: %w", err)
+	}
+	return &FileQueueStore[T]{dir: dir, lock: lock}, nil
+}
+
+// Close releases the store's exclusive lock on its directory.
+func (s *FileQueueStore[T]) Close() error {
+	if err := s.lock.Close(); err != nil {
+		return fmt.Errorf("closing queue store lock: %w", err)
+	}
+	return os.Remove(s.lock.Name())
+}
+
+func (s *FileQueueStore[T]) Put(seq uint64, id T, candidate TxCandidate) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(record[T]{Seq: seq, ID: id, Candidate: candidate}); err != nil {
+		return fmt.Errorf("encoding queue record %d: %w", seq, err)
+	}
+	path := s.path(seq)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("writing queue record %d: %w", seq, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("committing queue record %d: %w", seq, err)
+	}
+	return nil
+}
+
+func (s *FileQueueStore[T]) Delete(seq uint64) error {
+	if err := os.Remove(s.path(seq)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("deleting queue record %d: %w", seq, err)
+	}
+	return nil
+}
+
+func (s *FileQueueStore[T]) Iterate() ([]record[T], error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("listing queue store dir: %w", err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), recordExt) {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	// filenames are zero-padded seq numbers, so lexical order is seq order.
+	sort.Strings(names)
+
+	records := make([]record[T], 0, len(names))
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(s.dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("reading queue record %s: %w", name, err)
+		}
+		var rec record[T]
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&rec); err != nil {
+			return nil, fmt.Errorf("decoding queue record %s: %w", name, err)
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+func (s *FileQueueStore[T]) path(seq uint64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%020d%s", seq, recordExt))
+}
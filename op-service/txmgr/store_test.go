@@ -0,0 +1,63 @@
+package txmgr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileQueueStore_PutIterateDelete(t *testing.T) {
+	store, err := NewFileQueueStore[int](t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(3, 30, TxCandidate{}))
+	require.NoError(t, store.Put(1, 10, TxCandidate{}))
+	require.NoError(t, store.Put(2, 20, TxCandidate{}))
+
+	records, err := store.Iterate()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, []uint64{1, 2, 3}, []uint64{records[0].Seq, records[1].Seq, records[2].Seq},
+		"Iterate must return records in ascending seq order regardless of Put order")
+	require.Equal(t, 10, records[0].ID)
+
+	require.NoError(t, store.Delete(2))
+	records, err = store.Iterate()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, []uint64{1, 3}, []uint64{records[0].Seq, records[1].Seq})
+}
+
+func TestFileQueueStore_DeleteMissingIsNotAnError(t *testing.T) {
+	store, err := NewFileQueueStore[int](t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, store.Delete(42))
+}
+
+func TestFileQueueStore_SurvivesReopen(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileQueueStore[int](dir)
+	require.NoError(t, err)
+	require.NoError(t, store.Put(1, 10, TxCandidate{}))
+	require.NoError(t, store.Close())
+
+	reopened, err := NewFileQueueStore[int](dir)
+	require.NoError(t, err)
+	records, err := reopened.Iterate()
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, 10, records[0].ID)
+}
+
+func TestFileQueueStore_RefusesSecondOpenWhileLocked(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileQueueStore[int](dir)
+	require.NoError(t, err)
+
+	_, err = NewFileQueueStore[int](dir)
+	require.Error(t, err, "a second store must not be able to open the same dir while the first holds the lock")
+
+	require.NoError(t, store.Close())
+	_, err = NewFileQueueStore[int](dir)
+	require.NoError(t, err, "once the lock is released, the dir must be reopenable")
+}
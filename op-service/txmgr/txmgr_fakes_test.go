@@ -0,0 +1,74 @@
+package txmgr
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// fakeTxManager is a minimal TxManager for tests that only exercise Send.
+// Embedding the (nil) interface lets it satisfy TxManager's full method set
+// without having to implement methods these tests never call.
+type fakeTxManager struct {
+	TxManager
+	sendFn func(ctx context.Context, candidate TxCandidate) (*types.Receipt, error)
+}
+
+func (f *fakeTxManager) Send(ctx context.Context, candidate TxCandidate) (*types.Receipt, error) {
+	return f.sendFn(ctx, candidate)
+}
+
+// fakeQueueStore is an in-memory, concurrency-safe QueueStore for tests that
+// don't need real persistence, just observable Put/Delete/Iterate behavior
+// and ordering.
+type fakeQueueStore[T any] struct {
+	mu      sync.Mutex
+	records map[uint64]record[T]
+	calls   []string
+}
+
+func newFakeQueueStore[T any]() *fakeQueueStore[T] {
+	return &fakeQueueStore[T]{records: make(map[uint64]record[T])}
+}
+
+func (s *fakeQueueStore[T]) Put(seq uint64, id T, candidate TxCandidate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[seq] = record[T]{Seq: seq, ID: id, Candidate: candidate}
+	s.calls = append(s.calls, "put")
+	return nil
+}
+
+func (s *fakeQueueStore[T]) Delete(seq uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, seq)
+	s.calls = append(s.calls, "delete")
+	return nil
+}
+
+func (s *fakeQueueStore[T]) Iterate() ([]record[T], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]record[T], 0, len(s.records))
+	for _, rec := range s.records {
+		out = append(out, rec)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Seq < out[j].Seq })
+	return out, nil
+}
+
+func (s *fakeQueueStore[T]) has(seq uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.records[seq]
+	return ok
+}
+
+func (s *fakeQueueStore[T]) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.records)
+}